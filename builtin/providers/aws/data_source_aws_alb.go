@@ -0,0 +1,163 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsAlb() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsAlbRead,
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"internal": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"load_balancer_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"security_groups": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+				Set:      schema.HashString,
+			},
+
+			"subnets": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+				Set:      schema.HashString,
+			},
+
+			"access_logs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"prefix": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"enable_deletion_protection": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"idle_timeout": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"zone_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dns_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchemaComputed(),
+		},
+	}
+}
+
+func dataSourceAwsAlbRead(d *schema.ResourceData, meta interface{}) error {
+	elbconn := meta.(*AWSClient).elbv2conn
+	describeAlbOpts := &elbv2.DescribeLoadBalancersInput{}
+
+	if v, ok := d.GetOk("arn"); ok {
+		describeAlbOpts.LoadBalancerArns = []*string{aws.String(v.(string))}
+	} else if v, ok := d.GetOk("name"); ok {
+		describeAlbOpts.Names = []*string{aws.String(v.(string))}
+	}
+
+	describeResp, err := elbconn.DescribeLoadBalancers(describeAlbOpts)
+	if err != nil {
+		return errwrap.Wrapf("Error retrieving ALB: {{err}}", err)
+	}
+	if len(describeResp.LoadBalancers) != 1 {
+		return fmt.Errorf("Search returned %d ALBs, please revise so only one is returned", len(describeResp.LoadBalancers))
+	}
+
+	alb := describeResp.LoadBalancers[0]
+
+	d.SetId(*alb.LoadBalancerArn)
+	d.Set("arn", alb.LoadBalancerArn)
+	d.Set("name", alb.LoadBalancerName)
+	d.Set("internal", (alb.Scheme != nil && *alb.Scheme == "internal"))
+	d.Set("security_groups", flattenStringList(alb.SecurityGroups))
+	d.Set("subnets", flattenSubnetsFromAvailabilityZones(alb.AvailabilityZones))
+	d.Set("load_balancer_type", alb.Type)
+	d.Set("vpc_id", alb.VpcId)
+	d.Set("zone_id", alb.CanonicalHostedZoneId)
+	d.Set("dns_name", alb.DNSName)
+
+	respTags, err := elbconn.DescribeTags(&elbv2.DescribeTagsInput{
+		ResourceArns: []*string{alb.LoadBalancerArn},
+	})
+	if err != nil {
+		return errwrap.Wrapf("Error retrieving ALB Tags: {{err}}", err)
+	}
+
+	var et []*elbv2.Tag
+	if len(respTags.TagDescriptions) > 0 {
+		et = respTags.TagDescriptions[0].Tags
+	}
+	d.Set("tags", tagsToMapELBv2(et))
+
+	attributesResp, err := elbconn.DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(d.Id()),
+	})
+	if err != nil {
+		return errwrap.Wrapf("Error retrieving ALB Attributes: {{err}}", err)
+	}
+
+	accessLogMap, err := flattenAlbAttributes(d, attributesResp.Attributes)
+	if err != nil {
+		return err
+	}
+
+	if accessLogMap["bucket"] != "" || accessLogMap["prefix"] != "" {
+		d.Set("access_logs", []interface{}{accessLogMap})
+	} else {
+		d.Set("access_logs", []interface{}{})
+	}
+
+	return nil
+}
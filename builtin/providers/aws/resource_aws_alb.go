@@ -8,6 +8,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/wafregional"
 	"github.com/hashicorp/errwrap"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -23,6 +24,11 @@ func resourceAwsAlb() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -38,22 +44,62 @@ func resourceAwsAlb() *schema.Resource {
 				Computed: true,
 			},
 
+			"load_balancer_type": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Default:  "application",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(string)
+					if value != "application" && value != "network" {
+						errors = append(errors, fmt.Errorf(
+							"%q must be one of %q or %q", k, "application", "network"))
+					}
+					return
+				},
+			},
+
 			"security_groups": {
 				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				ForceNew: true,
 				Optional: true,
+				Computed: true,
 				Set:      schema.HashString,
 			},
 
 			"subnets": {
 				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
-				ForceNew: true,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				Set:      schema.HashString,
 			},
 
+			"subnet_mapping": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subnet_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"allocation_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"enable_cross_zone_load_balancing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"access_logs": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -84,6 +130,39 @@ func resourceAwsAlb() *schema.Resource {
 				Default:  60,
 			},
 
+			"enable_http2": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"drop_invalid_header_fields": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"desync_mitigation_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "defensive",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(string)
+					switch value {
+					case "monitor", "defensive", "strictest":
+					default:
+						errors = append(errors, fmt.Errorf(
+							"%q must be one of %q, %q or %q", k, "monitor", "defensive", "strictest"))
+					}
+					return
+				},
+			},
+
+			"associated_waf_web_acl_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"vpc_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -107,8 +186,11 @@ func resourceAwsAlb() *schema.Resource {
 func resourceAwsAlbCreate(d *schema.ResourceData, meta interface{}) error {
 	elbconn := meta.(*AWSClient).elbv2conn
 
+	albType := d.Get("load_balancer_type").(string)
+
 	elbOpts := &elbv2.CreateLoadBalancerInput{
 		Name: aws.String(d.Get("name").(string)),
+		Type: aws.String(albType),
 		Tags: tagsFromMapELBv2(d.Get("tags").(map[string]interface{})),
 	}
 
@@ -116,7 +198,8 @@ func resourceAwsAlbCreate(d *schema.ResourceData, meta interface{}) error {
 		elbOpts.Scheme = aws.String("internal")
 	}
 
-	if v, ok := d.GetOk("security_groups"); ok {
+	// Network Load Balancers do not support security groups.
+	if v, ok := d.GetOk("security_groups"); ok && albType != "network" {
 		elbOpts.SecurityGroups = expandStringList(v.(*schema.Set).List())
 	}
 
@@ -124,6 +207,10 @@ func resourceAwsAlbCreate(d *schema.ResourceData, meta interface{}) error {
 		elbOpts.Subnets = expandStringList(v.(*schema.Set).List())
 	}
 
+	if v, ok := d.GetOk("subnet_mapping"); ok {
+		elbOpts.SubnetMappings = expandAlbSubnetMappings(v.(*schema.Set).List())
+	}
+
 	log.Printf("[DEBUG] ALB create configuration: %#v", elbOpts)
 	var albArn string
 	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
@@ -148,9 +235,55 @@ func resourceAwsAlbCreate(d *schema.ResourceData, meta interface{}) error {
 	d.SetId(albArn)
 	log.Printf("[INFO] ALB ID: %s", d.Id())
 
+	if err := waitForAwsAlbActive(elbconn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
 	return resourceAwsAlbUpdate(d, meta)
 }
 
+// waitForAwsAlbActive polls DescribeLoadBalancers until the ALB reaches the
+// "active" state, so that dependent listener and target group resources do
+// not race against provisioning.
+func waitForAwsAlbActive(elbconn *elbv2.ELBV2, arn string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"provisioning"},
+		Target:  []string{"active", "active_impaired"},
+		Refresh: func() (interface{}, string, error) {
+			describeResp, err := elbconn.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+				LoadBalancerArns: []*string{aws.String(arn)},
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			if len(describeResp.LoadBalancers) != 1 {
+				return nil, "", fmt.Errorf("Unable to find ALB: %#v", describeResp.LoadBalancers)
+			}
+
+			alb := describeResp.LoadBalancers[0]
+			if alb.State == nil || alb.State.Code == nil {
+				return nil, "", nil
+			}
+
+			state := *alb.State.Code
+			if state == "failed" {
+				reason := ""
+				if alb.State.Reason != nil {
+					reason = *alb.State.Reason
+				}
+				return alb, state, fmt.Errorf("ALB failed to reach active state: %s", reason)
+			}
+
+			return alb, state, nil
+		},
+		Timeout: timeout,
+		Delay:   10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+	return err
+}
+
 func resourceAwsAlbRead(d *schema.ResourceData, meta interface{}) error {
 	elbconn := meta.(*AWSClient).elbv2conn
 	albArn := d.Id()
@@ -174,11 +307,14 @@ func resourceAwsAlbRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	alb := describeResp.LoadBalancers[0]
+	albType := aws.StringValue(alb.Type)
 
 	d.Set("name", alb.LoadBalancerName)
 	d.Set("internal", (alb.Scheme != nil && *alb.Scheme == "internal"))
 	d.Set("security_groups", flattenStringList(alb.SecurityGroups))
 	d.Set("subnets", flattenSubnetsFromAvailabilityZones(alb.AvailabilityZones))
+	d.Set("subnet_mapping", flattenAlbSubnetMappings(alb.AvailabilityZones))
+	d.Set("load_balancer_type", alb.Type)
 	d.Set("vpc_id", alb.VpcId)
 	d.Set("zone_id", alb.CanonicalHostedZoneId)
 	d.Set("dns_name", alb.DNSName)
@@ -203,8 +339,64 @@ func resourceAwsAlbRead(d *schema.ResourceData, meta interface{}) error {
 		return errwrap.Wrapf("Error retrieving ALB Attributes: {{err}}", err)
 	}
 
-	accessLogMap := map[string]interface{}{}
+	accessLogMap, err := flattenAlbAttributes(d, attributesResp.Attributes)
+	if err != nil {
+		return err
+	}
+
 	for _, attr := range attributesResp.Attributes {
+		switch *attr.Key {
+		case "load_balancing.cross_zone.enabled":
+			crossZoneEnabled := (*attr.Value) == "true"
+			log.Printf("[DEBUG] Setting ALB Cross Zone Load Balancing Enabled: %t", crossZoneEnabled)
+			d.Set("enable_cross_zone_load_balancing", crossZoneEnabled)
+		case "routing.http2.enabled":
+			http2Enabled := (*attr.Value) == "true"
+			log.Printf("[DEBUG] Setting ALB HTTP/2 Enabled: %t", http2Enabled)
+			d.Set("enable_http2", http2Enabled)
+		case "routing.http.drop_invalid_header_fields.enabled":
+			dropInvalidHeaderFieldsEnabled := (*attr.Value) == "true"
+			log.Printf("[DEBUG] Setting ALB Drop Invalid Header Fields Enabled: %t", dropInvalidHeaderFieldsEnabled)
+			d.Set("drop_invalid_header_fields", dropInvalidHeaderFieldsEnabled)
+		case "routing.http.desync_mitigation_mode":
+			log.Printf("[DEBUG] Setting ALB Desync Mitigation Mode: %s", *attr.Value)
+			d.Set("desync_mitigation_mode", *attr.Value)
+		}
+	}
+
+	// WAF Regional association is not supported for Network Load Balancers.
+	if albType != "network" {
+		wafConn := meta.(*AWSClient).wafregionalconn
+		webAclResp, err := wafConn.GetWebACLForResource(&wafregional.GetWebACLForResourceInput{
+			ResourceArn: alb.LoadBalancerArn,
+		})
+		if err != nil {
+			return errwrap.Wrapf("Error retrieving ALB WAF association: {{err}}", err)
+		}
+		if webAclResp.WebACLSummary != nil {
+			d.Set("associated_waf_web_acl_id", webAclResp.WebACLSummary.WebACLId)
+		} else {
+			d.Set("associated_waf_web_acl_id", "")
+		}
+	}
+
+	log.Printf("[DEBUG] Setting ALB Access Logs: %#v", accessLogMap)
+	if accessLogMap["bucket"] != "" || accessLogMap["prefix"] != "" {
+		d.Set("access_logs", []interface{}{accessLogMap})
+	} else {
+		d.Set("access_logs", []interface{}{})
+	}
+
+	return nil
+}
+
+// flattenAlbAttributes parses the common LoadBalancerAttributes shared by
+// resourceAwsAlbRead and dataSourceAwsAlbRead, setting idle_timeout and
+// enable_deletion_protection on d and returning the access_logs map so the
+// caller can finish populating the access_logs block.
+func flattenAlbAttributes(d *schema.ResourceData, attributes []*elbv2.LoadBalancerAttribute) (map[string]interface{}, error) {
+	accessLogMap := map[string]interface{}{}
+	for _, attr := range attributes {
 		switch *attr.Key {
 		case "access_logs.s3.bucket":
 			accessLogMap["bucket"] = *attr.Value
@@ -213,7 +405,7 @@ func resourceAwsAlbRead(d *schema.ResourceData, meta interface{}) error {
 		case "idle_timeout.timeout_seconds":
 			timeout, err := strconv.Atoi(*attr.Value)
 			if err != nil {
-				return errwrap.Wrapf("Error parsing ALB timeout: {{err}}", err)
+				return nil, errwrap.Wrapf("Error parsing ALB timeout: {{err}}", err)
 			}
 			log.Printf("[DEBUG] Setting ALB Timeout Seconds: %d", timeout)
 			d.Set("idle_timeout", timeout)
@@ -224,18 +416,12 @@ func resourceAwsAlbRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	log.Printf("[DEBUG] Setting ALB Access Logs: %#v", accessLogMap)
-	if accessLogMap["bucket"] != "" || accessLogMap["prefix"] != "" {
-		d.Set("access_logs", []interface{}{accessLogMap})
-	} else {
-		d.Set("access_logs", []interface{}{})
-	}
-
-	return nil
+	return accessLogMap, nil
 }
 
 func resourceAwsAlbUpdate(d *schema.ResourceData, meta interface{}) error {
 	elbconn := meta.(*AWSClient).elbv2conn
+	albType := d.Get("load_balancer_type").(string)
 
 	attributes := make([]*elbv2.LoadBalancerAttribute, 0)
 
@@ -275,13 +461,46 @@ func resourceAwsAlbUpdate(d *schema.ResourceData, meta interface{}) error {
 		})
 	}
 
-	if d.HasChange("idle_timeout") {
+	// Network Load Balancers do not support idle timeout configuration.
+	if d.HasChange("idle_timeout") && albType != "network" {
 		attributes = append(attributes, &elbv2.LoadBalancerAttribute{
 			Key:   aws.String("idle_timeout.timeout_seconds"),
 			Value: aws.String(fmt.Sprintf("%d", d.Get("idle_timeout").(int))),
 		})
 	}
 
+	// Cross-zone load balancing is always on for ALBs and only configurable for NLBs.
+	if d.HasChange("enable_cross_zone_load_balancing") && albType == "network" {
+		attributes = append(attributes, &elbv2.LoadBalancerAttribute{
+			Key:   aws.String("load_balancing.cross_zone.enabled"),
+			Value: aws.String(fmt.Sprintf("%t", d.Get("enable_cross_zone_load_balancing").(bool))),
+		})
+	}
+
+	// HTTP-layer attributes only apply to Application Load Balancers.
+	if albType != "network" {
+		if d.HasChange("enable_http2") {
+			attributes = append(attributes, &elbv2.LoadBalancerAttribute{
+				Key:   aws.String("routing.http2.enabled"),
+				Value: aws.String(fmt.Sprintf("%t", d.Get("enable_http2").(bool))),
+			})
+		}
+
+		if d.HasChange("drop_invalid_header_fields") {
+			attributes = append(attributes, &elbv2.LoadBalancerAttribute{
+				Key:   aws.String("routing.http.drop_invalid_header_fields.enabled"),
+				Value: aws.String(fmt.Sprintf("%t", d.Get("drop_invalid_header_fields").(bool))),
+			})
+		}
+
+		if d.HasChange("desync_mitigation_mode") {
+			attributes = append(attributes, &elbv2.LoadBalancerAttribute{
+				Key:   aws.String("routing.http.desync_mitigation_mode"),
+				Value: aws.String(d.Get("desync_mitigation_mode").(string)),
+			})
+		}
+	}
+
 	if len(attributes) != 0 {
 		input := &elbv2.ModifyLoadBalancerAttributesInput{
 			LoadBalancerArn: aws.String(d.Id()),
@@ -293,6 +512,56 @@ func resourceAwsAlbUpdate(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("Failure configuring ALB attributes: %s", err)
 		}
+
+		if err := waitForAwsAlbActive(elbconn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	if !d.IsNewResource() && (d.HasChange("subnets") || d.HasChange("subnet_mapping")) {
+		setSubnetsInput := &elbv2.SetSubnetsInput{
+			LoadBalancerArn: aws.String(d.Id()),
+		}
+
+		if v, ok := d.GetOk("subnet_mapping"); ok && len(v.(*schema.Set).List()) > 0 {
+			setSubnetsInput.SubnetMappings = expandAlbSubnetMappings(v.(*schema.Set).List())
+		} else if v, ok := d.GetOk("subnets"); ok {
+			setSubnetsInput.Subnets = expandStringList(v.(*schema.Set).List())
+		}
+
+		_, err := elbconn.SetSubnets(setSubnetsInput)
+		if err != nil {
+			return fmt.Errorf("Failure setting ALB subnets: %s", err)
+		}
+
+		if err := waitForAwsAlbActive(elbconn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	// WAF Regional association is not supported for Network Load Balancers.
+	if albType != "network" && d.HasChange("associated_waf_web_acl_id") {
+		wafConn := meta.(*AWSClient).wafregionalconn
+		oldAcl, newAcl := d.GetChange("associated_waf_web_acl_id")
+
+		if oldAcl.(string) != "" {
+			_, err := wafConn.DisassociateWebACL(&wafregional.DisassociateWebACLInput{
+				ResourceArn: aws.String(d.Id()),
+			})
+			if err != nil {
+				return errwrap.Wrapf("Error disassociating ALB from WAF Web ACL: {{err}}", err)
+			}
+		}
+
+		if newAcl.(string) != "" {
+			_, err := wafConn.AssociateWebACL(&wafregional.AssociateWebACLInput{
+				ResourceArn: aws.String(d.Id()),
+				WebACLId:    aws.String(newAcl.(string)),
+			})
+			if err != nil {
+				return errwrap.Wrapf("Error associating ALB with WAF Web ACL: {{err}}", err)
+			}
+		}
 	}
 
 	return resourceAwsAlbRead(d, meta)
@@ -301,6 +570,17 @@ func resourceAwsAlbUpdate(d *schema.ResourceData, meta interface{}) error {
 func resourceAwsAlbDelete(d *schema.ResourceData, meta interface{}) error {
 	albconn := meta.(*AWSClient).elbv2conn
 
+	// WAF Regional association is not supported for Network Load Balancers.
+	if d.Get("load_balancer_type").(string) != "network" && d.Get("associated_waf_web_acl_id").(string) != "" {
+		wafConn := meta.(*AWSClient).wafregionalconn
+		_, err := wafConn.DisassociateWebACL(&wafregional.DisassociateWebACLInput{
+			ResourceArn: aws.String(d.Id()),
+		})
+		if err != nil {
+			return errwrap.Wrapf("Error disassociating ALB from WAF Web ACL: {{err}}", err)
+		}
+	}
+
 	log.Printf("[INFO] Deleting ALB: %s", d.Id())
 
 	// Destroy the load balancer
@@ -346,3 +626,39 @@ func flattenSubnetsFromAvailabilityZones(availabilityZones []*elbv2.Availability
 	}
 	return result
 }
+
+// expandAlbSubnetMappings turns a list of subnet_mapping blocks into ELBv2 SubnetMappings,
+// used to assign Elastic IPs per Availability Zone for Network Load Balancers.
+func expandAlbSubnetMappings(list []interface{}) []*elbv2.SubnetMapping {
+	mappings := make([]*elbv2.SubnetMapping, 0, len(list))
+	for _, m := range list {
+		sm := m.(map[string]interface{})
+		mapping := &elbv2.SubnetMapping{
+			SubnetId: aws.String(sm["subnet_id"].(string)),
+		}
+
+		if v, ok := sm["allocation_id"]; ok && v.(string) != "" {
+			mapping.AllocationId = aws.String(v.(string))
+		}
+
+		mappings = append(mappings, mapping)
+	}
+	return mappings
+}
+
+// flattenAlbSubnetMappings creates a list of subnet_mapping blocks from the AvailabilityZones
+// structure returned by the API.
+func flattenAlbSubnetMappings(availabilityZones []*elbv2.AvailabilityZone) []interface{} {
+	l := make([]interface{}, 0, len(availabilityZones))
+	for _, az := range availabilityZones {
+		// Internal NLBs have no Elastic IP, so AllocationId is absent;
+		// aws.StringValue(nil) yields "" and the subnet still round-trips.
+		for _, address := range az.LoadBalancerAddresses {
+			l = append(l, map[string]interface{}{
+				"subnet_id":     aws.StringValue(az.SubnetId),
+				"allocation_id": aws.StringValue(address.AllocationId),
+			})
+		}
+	}
+	return l
+}